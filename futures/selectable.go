@@ -18,25 +18,32 @@ limitations under the License.
 package futures
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // ErrFutureCanceled signals that futures in canceled by a call to `f.Cancel()`
 var ErrFutureCanceled = errors.New("future canceled")
 
+// ErrFutureTimeout signals that GetResultTimeout returned because its
+// deadline elapsed before the future was filled.
+var ErrFutureTimeout = errors.New("future: timed out waiting for result")
+
 // Selectable is a future with channel exposed for external `select`.
 // Many simultaneous listeners may wait for result either with `f.Value()`
 // or by selecting/fetching from `f.WaitChan()`, which is closed when future
 // fulfilled.
 // Selectable contains sync.Mutex, so it is not movable/copyable.
 type Selectable[T any] struct {
-	m      sync.Mutex
-	val    T
-	err    error
-	wait   chan struct{}
-	filled uint32
+	m         sync.Mutex
+	val       T
+	err       error
+	wait      chan struct{}
+	filled    uint32
+	callbacks []func(T, error)
 }
 
 // NewSelectable returns new selectable future.
@@ -73,21 +80,78 @@ func (f *Selectable[T]) GetResult() (T, error) {
 	return f.val, f.err
 }
 
+// GetResultTimeout waits up to d for the future to be fulfilled and returns
+// value or error, whatever is set first. If d elapses before the future is
+// filled, ErrFutureTimeout is returned. It replaces the
+// `select { case <-f.WaitChan(): ... case <-time.After(d): ... }` callers
+// would otherwise have to write by hand.
+func (f *Selectable[T]) GetResultTimeout(d time.Duration) (T, error) {
+	if atomic.LoadUint32(&f.filled) == 1 {
+		return f.val, f.err
+	}
+	select {
+	case <-f.wchan():
+		return f.val, f.err
+	case <-time.After(d):
+		var zero T
+		return zero, ErrFutureTimeout
+	}
+}
+
+// GetResultContext waits for the future to be fulfilled and returns value or
+// error, whatever is set first. If ctx is done before the future is filled,
+// ctx.Err() is returned.
+func (f *Selectable[T]) GetResultContext(ctx context.Context) (T, error) {
+	if atomic.LoadUint32(&f.filled) == 1 {
+		return f.val, f.err
+	}
+	select {
+	case <-f.wchan():
+		return f.val, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// AddCallback registers fn to be called with the future's value and error
+// once it is filled. If the future is already filled, fn runs immediately
+// on the calling goroutine. Otherwise fn runs synchronously from whichever
+// goroutine calls Fill, letting callers chain off a future without spawning
+// a goroutine per waiter.
+func (f *Selectable[T]) AddCallback(fn func(T, error)) {
+	f.m.Lock()
+	if f.filled == 1 {
+		val, err := f.val, f.err
+		f.m.Unlock()
+		fn(val, err)
+		return
+	}
+	f.callbacks = append(f.callbacks, fn)
+	f.m.Unlock()
+}
+
 // Fill sets value for future, if it were not already fullfilled
 // Returns error, if it were already set to future.
 func (f *Selectable[T]) Fill(v T, e error) error {
 	f.m.Lock()
+	var callbacks []func(T, error)
 	if f.filled == 0 {
 		f.val = v
 		f.err = e
 		atomic.StoreUint32(&f.filled, 1)
 		w := f.wait
 		f.wait = closed
+		callbacks = f.callbacks
+		f.callbacks = nil
 		if w != nil {
 			close(w)
 		}
 	}
 	f.m.Unlock()
+	for _, cb := range callbacks {
+		cb(v, e)
+	}
 	return f.err
 }
 
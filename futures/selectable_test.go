@@ -0,0 +1,66 @@
+package futures
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSelectableAddCallbackBeforeFillRunsSynchronouslyOnFillGoroutine(t *testing.T) {
+	f := NewSelectable[int]()
+
+	calls := 0
+	f.AddCallback(func(v int, err error) {
+		calls++
+		if v != 42 || err != nil {
+			t.Errorf("callback got (%d, %v), want (42, nil)", v, err)
+		}
+	})
+
+	f.Fill(42, nil)
+
+	// Fill runs callbacks on its own goroutine before returning, so the
+	// callback must already have fired by the time Fill returns above.
+	if calls != 1 {
+		t.Fatalf("expected callback to run exactly once by the time Fill returns, ran %d times", calls)
+	}
+}
+
+func TestSelectableAddCallbackAfterFillRunsImmediatelyOnCaller(t *testing.T) {
+	f := NewSelectable[int]()
+	f.Fill(7, nil)
+
+	var called bool
+	f.AddCallback(func(v int, err error) {
+		called = true
+		if v != 7 || err != nil {
+			t.Errorf("callback got (%d, %v), want (7, nil)", v, err)
+		}
+	})
+
+	if !called {
+		t.Fatal("callback registered after Fill did not run immediately")
+	}
+}
+
+func TestSelectableGetResultTimeoutFiresBeforeFill(t *testing.T) {
+	f := NewSelectable[int]()
+
+	_, err := f.GetResultTimeout(10 * time.Millisecond)
+	if err != ErrFutureTimeout {
+		t.Fatalf("expected ErrFutureTimeout, got %v", err)
+	}
+}
+
+func TestSelectableGetResultContextCanceledBeforeFill(t *testing.T) {
+	f := NewSelectable[int]()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := f.GetResultContext(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,95 @@
+package queue
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQueueNewBoundedOfferReturnsErrFullWhenAtCapacity(t *testing.T) {
+	q := NewBounded[int](0, 2)
+
+	if err := q.Offer(1, 2); err != nil {
+		t.Fatalf("Offer: %v", err)
+	}
+
+	if err := q.Offer(3); err != ErrFull {
+		t.Fatalf("expected ErrFull, got %v", err)
+	}
+}
+
+func TestQueueNewBoundedOfferRejectsBatchLargerThanCapacity(t *testing.T) {
+	q := NewBounded[int](0, 2)
+
+	if err := q.Offer(1, 2, 3); err != ErrFull {
+		t.Fatalf("expected ErrFull for an oversized batch, got %v", err)
+	}
+}
+
+func TestQueueNewBoundedPutBlocksUntilRoom(t *testing.T) {
+	q := NewBounded[int](0, 1)
+	if err := q.Put(1); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- q.Put(2)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Put returned before capacity was freed")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if _, err := q.Get(1); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Put did not unblock after Get freed capacity")
+	}
+}
+
+// TestQueueNewBoundedMultiProducerWakeup fills a bounded queue to capacity,
+// blocks several producers on Put, then drains the whole queue in a single
+// Get. Every producer whose batch now fits must unblock, not just one of
+// them.
+func TestQueueNewBoundedMultiProducerWakeup(t *testing.T) {
+	q := NewBounded[int](0, 10)
+	for i := 0; i < 10; i++ {
+		if err := q.Put(i); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+
+	const producers = 5
+	done := make(chan error, producers)
+	for i := 0; i < producers; i++ {
+		go func(i int) {
+			done <- q.Put(100 + i)
+		}(i)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if _, err := q.Get(10); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	for i := 0; i < producers; i++ {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("only %d/%d producers unblocked after Get freed capacity", i, producers)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+/*
+Copyright 2014 Workiva, LLC
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+ http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// delayItem wraps a value placed into a DelayQueue along with the time it
+// becomes eligible for delivery and its tie-breaking priority.
+type delayItem[T any] struct {
+	value    T
+	ready    time.Time
+	priority int64
+	index    int
+}
+
+// delayHeap is a container/heap.Interface ordered by (ready, priority), with
+// the soonest-ready item with the lowest priority value at the root.
+type delayHeap[T any] []*delayItem[T]
+
+func (h delayHeap[T]) Len() int { return len(h) }
+
+func (h delayHeap[T]) Less(i, j int) bool {
+	if !h[i].ready.Equal(h[j].ready) {
+		return h[i].ready.Before(h[j].ready)
+	}
+	return h[i].priority < h[j].priority
+}
+
+func (h delayHeap[T]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *delayHeap[T]) Push(x interface{}) {
+	item := x.(*delayItem[T])
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *delayHeap[T]) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*h = old[:n-1]
+	return item
+}
+
+// DelayQueue is a threadsafe priority queue whose items each carry a ready
+// time; Get/Poll only ever return an item once its ready time has passed.
+// Among items that are ready, lower priority values are returned first.
+// Like Queue, a consumer blocked in Get is released early by Dispose.
+type DelayQueue[T any] struct {
+	waiters  waiters
+	items    delayHeap[T]
+	lock     sync.Mutex
+	disposed bool
+}
+
+// NewDelayQueue is a constructor for a new threadsafe DelayQueue.
+func NewDelayQueue[T any](hint int64) *DelayQueue[T] {
+	return &DelayQueue[T]{
+		items: make(delayHeap[T], 0, hint),
+	}
+}
+
+// Put adds value to the queue, eligible for delivery once ready has passed.
+// priority breaks ties between items that become ready at the same time;
+// lower values are returned first.
+func (q *DelayQueue[T]) Put(value T, ready time.Time, priority int64) error {
+	q.lock.Lock()
+
+	if q.disposed {
+		q.lock.Unlock()
+		return ErrDisposed
+	}
+
+	item := &delayItem[T]{value: value, ready: ready, priority: priority}
+	heap.Push(&q.items, item)
+
+	if q.items[0] == item {
+		// This item is now the next one due; wake a waiter so it can
+		// re-arm its timer against the new, earlier deadline.
+		if sema := q.waiters.get(); sema != nil {
+			sema.response.Add(1)
+			select {
+			case sema.ready <- true:
+				sema.response.Wait()
+			default:
+			}
+		}
+	}
+
+	q.lock.Unlock()
+	return nil
+}
+
+// Get retrieves the next item from the queue once its ready time has
+// passed, blocking until that happens or the queue is disposed.
+func (q *DelayQueue[T]) Get() (T, error) {
+	return q.Poll(0)
+}
+
+// Poll behaves like Get, but returns ErrTimeout if no item becomes ready
+// within timeout. A non-positive timeout blocks until an item is ready.
+func (q *DelayQueue[T]) Poll(timeout time.Duration) (T, error) {
+	var zero T
+
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+
+	for {
+		q.lock.Lock()
+
+		if q.disposed {
+			q.lock.Unlock()
+			return zero, ErrDisposed
+		}
+
+		if len(q.items) > 0 && !q.items[0].ready.After(time.Now()) {
+			item := heap.Pop(&q.items).(*delayItem[T])
+			q.lock.Unlock()
+			return item.value, nil
+		}
+
+		sema := newSema()
+		q.waiters.put(sema)
+
+		var wait time.Duration
+		haveWait := false
+		if len(q.items) > 0 {
+			wait = q.items[0].ready.Sub(time.Now())
+			haveWait = true
+		}
+		if !deadline.IsZero() {
+			if remaining := deadline.Sub(time.Now()); !haveWait || remaining < wait {
+				wait = remaining
+				haveWait = true
+			}
+		}
+
+		q.lock.Unlock()
+
+		var timeoutC <-chan time.Time
+		if haveWait {
+			timeoutC = time.After(wait)
+		}
+
+		select {
+		case <-sema.ready:
+			sema.response.Done()
+		case <-timeoutC:
+			// cleanup the sema that was added to waiters, mirroring the
+			// timeout path in queue.go's PollContext
+			select {
+			case sema.ready <- true:
+				// We got here before Put() could; remove sema from waiters.
+				q.lock.Lock()
+				q.waiters.remove(sema)
+				q.lock.Unlock()
+			default:
+				// Put() already claimed this sema and is blocked in
+				// response.Wait(); let it proceed.
+				sema.response.Done()
+			}
+
+			if !deadline.IsZero() && !time.Now().Before(deadline) {
+				return zero, ErrTimeout
+			}
+			// Either a new, earlier item arrived or our wait was just for
+			// the (not yet ready) head of the heap; loop and re-check.
+		}
+	}
+}
+
+// Len returns the number of items in this queue.
+func (q *DelayQueue[T]) Len() int64 {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return int64(len(q.items))
+}
+
+// Disposed returns a bool indicating if this queue has had Dispose called
+// on it.
+func (q *DelayQueue[T]) Disposed() bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return q.disposed
+}
+
+// Dispose will dispose of this queue. Any subsequent calls to Get or Put
+// will return ErrDisposed.
+func (q *DelayQueue[T]) Dispose() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.disposed = true
+	for _, waiter := range q.waiters {
+		waiter.response.Add(1)
+		select {
+		case waiter.ready <- true:
+			// release Poll immediately
+		default:
+			// ignore if it's already been woken
+		}
+	}
+
+	q.items = nil
+	q.waiters = nil
+}
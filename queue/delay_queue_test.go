@@ -0,0 +1,109 @@
+package queue
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDelayQueueOrdersByReadyThenPriority(t *testing.T) {
+	q := NewDelayQueue[string](0)
+	now := time.Now()
+
+	q.Put("late", now.Add(20*time.Millisecond), 0)
+	q.Put("early-low-priority", now.Add(5*time.Millisecond), 1)
+	q.Put("early-high-priority", now.Add(5*time.Millisecond), 0)
+
+	first, err := q.Poll(time.Second)
+	if err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	if first != "early-high-priority" {
+		t.Fatalf("expected early-high-priority first, got %s", first)
+	}
+
+	second, err := q.Poll(time.Second)
+	if err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	if second != "early-low-priority" {
+		t.Fatalf("expected early-low-priority second, got %s", second)
+	}
+
+	third, err := q.Poll(time.Second)
+	if err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	if third != "late" {
+		t.Fatalf("expected late third, got %s", third)
+	}
+}
+
+func TestDelayQueuePollTimeout(t *testing.T) {
+	q := NewDelayQueue[int](0)
+	q.Put(1, time.Now().Add(time.Hour), 0)
+
+	_, err := q.Poll(10 * time.Millisecond)
+	if err != ErrTimeout {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+}
+
+func TestDelayQueueDispose(t *testing.T) {
+	q := NewDelayQueue[int](0)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := q.Get()
+		done <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	q.Dispose()
+
+	select {
+	case err := <-done:
+		if err != ErrDisposed {
+			t.Fatalf("expected ErrDisposed, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Get did not return after Dispose")
+	}
+}
+
+// TestDelayQueuePutPollTimeoutRace stresses the race between a Put waking a
+// waiter and that waiter's timeout firing at nearly the same instant. Before
+// the timeout branch mirrored queue.go's poison/Done handshake, a Put losing
+// that race would hang forever in sema.response.Wait().
+func TestDelayQueuePutPollTimeoutRace(t *testing.T) {
+	q := NewDelayQueue[int](0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			q.Put(i, time.Now(), 0)
+		}(i)
+	}
+
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.Poll(time.Microsecond)
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Put/Poll goroutines did not finish; a Put is likely stuck in sema.response.Wait()")
+	}
+}
@@ -52,12 +52,30 @@ TODO: Unify the types of queue to the same interface.
 package queue
 
 import (
+	"context"
+	"errors"
 	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
+// ErrDisposed is returned by any operation performed against a queue that
+// has already had Dispose called on it.
+var ErrDisposed = errors.New("queue: disposed")
+
+// ErrTimeout is returned by Poll when the timeout elapses before an item
+// becomes available.
+var ErrTimeout = errors.New("queue: poll timed out")
+
+// ErrEmptyQueue is returned by Peek when the queue is empty.
+var ErrEmptyQueue = errors.New("queue: empty queue")
+
+// ErrFull is returned by Offer when the queue was created with NewBounded
+// and adding the items would push it over capacity, and by both Put and
+// Offer for a batch larger than the capacity.
+var ErrFull = errors.New("queue: queue is full")
+
 type waiters []*sema
 
 func (w *waiters) get() *sema {
@@ -151,6 +169,9 @@ func (items *items[T]) getUntil(checker func(item T) bool) []T {
 type sema struct {
 	ready    chan bool
 	response *sync.WaitGroup
+	// need is the batch size a Put blocked on this sema is waiting to fit
+	// under capacity. It is unused (left zero) for consumer waiters.
+	need int64
 }
 
 func newSema() *sema {
@@ -163,15 +184,32 @@ func newSema() *sema {
 // Queue is the struct responsible for tracking the state
 // of the queue.
 type Queue[T any] struct {
-	waiters  waiters
-	items    items[T]
-	lock     sync.Mutex
-	disposed bool
+	waiters    waiters
+	putWaiters waiters
+	items      items[T]
+	lock       sync.Mutex
+	disposed   bool
+	capacity   int64
 }
 
-// Put will add the specified items to the queue.
+// Put will add the specified items to the queue.  If the queue was created
+// with NewBounded and adding items would push it over capacity, Put blocks
+// until enough items have been Get/Poll'd to make room or the queue is
+// disposed, in which case ErrDisposed is returned.  A batch larger than the
+// capacity can never fit, so Put returns ErrFull for it without blocking.
 func (q *Queue[T]) Put(items ...T) error {
-	if len(items) == 0 {
+	return q.put(items, true)
+}
+
+// Offer behaves like Put, except that if the queue is bounded and adding
+// items would push it over capacity, it returns ErrFull immediately instead
+// of blocking.
+func (q *Queue[T]) Offer(items ...T) error {
+	return q.put(items, false)
+}
+
+func (q *Queue[T]) put(newItems []T, block bool) error {
+	if len(newItems) == 0 {
 		return nil
 	}
 
@@ -182,7 +220,35 @@ func (q *Queue[T]) Put(items ...T) error {
 		return ErrDisposed
 	}
 
-	q.items = append(q.items, items...)
+	if q.capacity > 0 && int64(len(newItems)) > q.capacity {
+		// This batch can never fit even against an empty queue; blocking
+		// would wait forever instead of ever being satisfied.
+		q.lock.Unlock()
+		return ErrFull
+	}
+
+	for q.capacity > 0 && int64(len(q.items))+int64(len(newItems)) > q.capacity {
+		if !block {
+			q.lock.Unlock()
+			return ErrFull
+		}
+
+		sema := newSema()
+		sema.need = int64(len(newItems))
+		q.putWaiters.put(sema)
+		q.lock.Unlock()
+
+		<-sema.ready
+		sema.response.Done()
+
+		q.lock.Lock()
+		if q.disposed {
+			q.lock.Unlock()
+			return ErrDisposed
+		}
+	}
+
+	q.items = append(q.items, newItems...)
 	for {
 		sema := q.waiters.get()
 		if sema == nil {
@@ -204,6 +270,36 @@ func (q *Queue[T]) Put(items ...T) error {
 	return nil
 }
 
+// wakePutWaiters releases producers blocked in Put for as long as the
+// capacity freed by a Get/Poll/TakeUntil can fit the next queued producer's
+// batch, mirroring the Get-waiter loop in put() instead of waking only one
+// producer regardless of how much room was actually freed. Callers must
+// either hold q.lock or be running inside the handoff window where a Put
+// still holds it on their behalf (see the sema.ready case in PollContext).
+func (q *Queue[T]) wakePutWaiters() {
+	// reserved tracks capacity already handed out to producers woken
+	// earlier in this same call, since none of them can actually append
+	// their items (and so shrink the real room) until they reacquire
+	// q.lock after this function returns.
+	var reserved int64
+	for len(q.putWaiters) > 0 {
+		next := q.putWaiters[0]
+		if q.capacity > 0 && int64(len(q.items))+reserved+next.need > q.capacity {
+			break
+		}
+		reserved += next.need
+
+		sema := q.putWaiters.get()
+		sema.response.Add(1)
+		select {
+		case sema.ready <- true:
+			sema.response.Wait()
+		default:
+			// The producer's sema was already signalled.
+		}
+	}
+}
+
 // Get retrieves items from the queue.  If there are some items in the
 // queue, get will return a number UP TO the number passed in as a
 // parameter.  If no items are in the queue, this method will pause
@@ -212,12 +308,30 @@ func (q *Queue[T]) Get(number int64) ([]T, error) {
 	return q.Poll(number, 0)
 }
 
+// GetContext retrieves items from the queue like Get, except the wait for
+// items can also be abandoned by cancelling ctx, in which case ctx.Err() is
+// returned. This is useful for callers (an RPC handler streaming to a
+// client, for example) that need to stop waiting as soon as their own
+// caller goes away, rather than only on Dispose or a fixed timeout.
+func (q *Queue[T]) GetContext(ctx context.Context, number int64) ([]T, error) {
+	return q.PollContext(ctx, number, 0)
+}
+
 // Poll retrieves items from the queue.  If there are some items in the queue,
 // Poll will return a number UP TO the number passed in as a parameter.  If no
 // items are in the queue, this method will pause until items are added to the
 // queue or the provided timeout is reached.  A non-positive timeout will block
 // until items are added.  If a timeout occurs, ErrTimeout is returned.
 func (q *Queue[T]) Poll(number int64, timeout time.Duration) ([]T, error) {
+	return q.PollContext(context.Background(), number, timeout)
+}
+
+// PollContext behaves like Poll, but the wait can additionally be cancelled
+// via ctx, mirroring the timeout path: if ctx is done first, the waiting
+// sema is removed from q.waiters (or, if Put already claimed it, drained so
+// Put can proceed) and ctx.Err() is returned. ctx must be non-nil; pass
+// context.Background() for a ctx-less wait.
+func (q *Queue[T]) PollContext(ctx context.Context, number int64, timeout time.Duration) ([]T, error) {
 	if number < 1 {
 		// thanks again go
 		return []T{}, nil
@@ -248,6 +362,7 @@ func (q *Queue[T]) Poll(number int64, timeout time.Duration) ([]T, error) {
 				return nil, ErrDisposed
 			}
 			items = q.items.get(number)
+			q.wakePutWaiters()
 			sema.response.Done()
 			return items, nil
 		case <-timeoutC:
@@ -264,10 +379,22 @@ func (q *Queue[T]) Poll(number int64, timeout time.Duration) ([]T, error) {
 				sema.response.Done()
 			}
 			return nil, ErrTimeout
+		case <-ctx.Done():
+			// same cleanup as the timeout path above
+			select {
+			case sema.ready <- true:
+				q.lock.Lock()
+				q.waiters.remove(sema)
+				q.lock.Unlock()
+			default:
+				sema.response.Done()
+			}
+			return nil, ctx.Err()
 		}
 	}
 
 	items = q.items.get(number)
+	q.wakePutWaiters()
 	q.lock.Unlock()
 	return items, nil
 }
@@ -308,6 +435,7 @@ func (q *Queue[T]) TakeUntil(checker func(item T) bool) ([]T, error) {
 	}
 
 	result := q.items.getUntil(checker)
+	q.wakePutWaiters()
 	q.lock.Unlock()
 	return result, nil
 }
@@ -354,22 +482,45 @@ func (q *Queue[T]) Dispose() []T {
 			// ignore if it's a timeout or in the get
 		}
 	}
+	for _, waiter := range q.putWaiters {
+		waiter.response.Add(1)
+		select {
+		case waiter.ready <- true:
+			// release the blocked Put immediately
+		default:
+			// ignore if it's already been woken
+		}
+	}
 
 	disposedItems := q.items
 
 	q.items = nil
 	q.waiters = nil
+	q.putWaiters = nil
 
 	return disposedItems
 }
 
-// New is a constructor for a new threadsafe queue.
+// New is a constructor for a new threadsafe queue.  The queue grows without
+// bound; use NewBounded for backpressured producers.
 func New[T any](hint int64) *Queue[T] {
 	return &Queue[T]{
 		items: make([]T, 0, hint),
 	}
 }
 
+// NewBounded is a constructor for a threadsafe queue with a fixed capacity.
+// Once the queue holds cap items, Put blocks until room is made by a
+// Get/Poll/TakeUntil (or the queue is disposed), and Offer returns ErrFull
+// instead of blocking.  This gives producers the same backpressure a
+// buffered channel would provide, which the plain, unbounded Queue does not.
+func NewBounded[T any](hint, cap int64) *Queue[T] {
+	return &Queue[T]{
+		items:    make([]T, 0, hint),
+		capacity: cap,
+	}
+}
+
 // ExecuteInParallel will (in parallel) call the provided function
 // with each item in the queue until the queue is exhausted.  When the queue
 // is exhausted execution is complete and all goroutines will be killed.
@@ -0,0 +1,81 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQueuePollContextCanceledBeforePut(t *testing.T) {
+	q := New[int](0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := q.PollContext(ctx, 1, 0)
+	if err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestQueueGetContextCancelUnblocksWaiter(t *testing.T) {
+	q := New[int](0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := q.GetContext(ctx, 1)
+		done <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("GetContext did not return after ctx was canceled")
+	}
+}
+
+// TestQueueGetContextCancelRacesPut stresses the race between a Put waking a
+// waiter and that waiter's ctx firing at nearly the same instant, the same
+// hazard TestDelayQueuePutPollTimeoutRace covers for timeouts.
+func TestQueueGetContextCancelRacesPut(t *testing.T) {
+	q := New[int](0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			q.Put(i)
+		}(i)
+	}
+
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Microsecond)
+			defer cancel()
+			q.GetContext(ctx, 1)
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Put/GetContext goroutines did not finish; a Put is likely stuck in sema.response.Wait()")
+	}
+}
@@ -29,6 +29,7 @@ package set
 
 import (
 	"sync"
+	"unsafe"
 )
 
 // Set is an implementation of ISet using the builtin map type. Set is threadsafe.
@@ -121,6 +122,112 @@ func (set *Set[T]) All(items ...T) bool {
 	return true
 }
 
+// Iterate calls fn with each item in the set, holding the set's read lock
+// for the duration of the call so the set cannot be mutated concurrently.
+// Iteration stops as soon as fn returns false.
+func (set *Set[T]) Iterate(fn func(item T) bool) {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+
+	for item := range set.items {
+		if !fn(item) {
+			return
+		}
+	}
+}
+
+// lockOrder returns a and b ordered by address so binary set operations can
+// lock both sets without risking a deadlock against a concurrent call that
+// combines the same two sets in the opposite order.
+func lockOrder[T comparable](a, b *Set[T]) (*Set[T], *Set[T]) {
+	if uintptr(unsafe.Pointer(a)) <= uintptr(unsafe.Pointer(b)) {
+		return a, b
+	}
+	return b, a
+}
+
+// rlockBoth read-locks set and other, in address order, and returns the
+// unlock func to defer. If set and other are the same set, it is locked
+// only once.
+func (set *Set[T]) rlockBoth(other *Set[T]) func() {
+	first, second := lockOrder(set, other)
+	first.lock.RLock()
+	if second == first {
+		return first.lock.RUnlock
+	}
+	second.lock.RLock()
+	return func() {
+		second.lock.RUnlock()
+		first.lock.RUnlock()
+	}
+}
+
+// Union returns a new set containing every item present in set, other, or
+// both.
+func (set *Set[T]) Union(other *Set[T]) *Set[T] {
+	unlock := set.rlockBoth(other)
+	defer unlock()
+
+	result := New[T]()
+	for item := range set.items {
+		result.items[item] = struct{}{}
+	}
+	for item := range other.items {
+		result.items[item] = struct{}{}
+	}
+	return result
+}
+
+// Intersection returns a new set containing only the items present in both
+// set and other.
+func (set *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	unlock := set.rlockBoth(other)
+	defer unlock()
+
+	result := New[T]()
+	for item := range set.items {
+		if _, ok := other.items[item]; ok {
+			result.items[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Difference returns a new set containing the items present in set but not
+// in other.
+func (set *Set[T]) Difference(other *Set[T]) *Set[T] {
+	unlock := set.rlockBoth(other)
+	defer unlock()
+
+	result := New[T]()
+	for item := range set.items {
+		if _, ok := other.items[item]; !ok {
+			result.items[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// SymmetricDifference returns a new set containing the items present in
+// exactly one of set or other.
+func (set *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	unlock := set.rlockBoth(other)
+	defer unlock()
+
+	result := New[T]()
+	for item := range set.items {
+		if _, ok := other.items[item]; !ok {
+			result.items[item] = struct{}{}
+		}
+	}
+	for item := range other.items {
+		if _, ok := set.items[item]; !ok {
+			result.items[item] = struct{}{}
+		}
+	}
+	return result
+}
+
 // Dispose will add this set back into the pool.
 func (set *Set[T]) Dispose() {
 	set.lock.Lock()